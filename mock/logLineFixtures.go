@@ -0,0 +1,18 @@
+package mock
+
+import (
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+// NewBenchmarkLogLineWrapper returns a LogLineWrapper whose marshaled size is
+// close to a typical 512-byte log line, for use in allocation benchmarks.
+func NewBenchmarkLogLineWrapper() *logger.LogLineWrapper {
+	return &logger.LogLineWrapper{
+		LoggerName:  "benchmark",
+		Correlation: "0123456789abcdef0123456789abcdef",
+		Message:     "the quick brown fox jumps over the lazy dog, over and over again until the message is roughly five hundred and twelve bytes long, which is a representative size for a single structured log line emitted by a busy node while it keeps processing blocks, validating transactions, gossiping headers with its peers and writing state changes to disk, all of which tend to produce a steady stream of fairly verbose trace and debug output",
+		LogLevel:    0,
+		Timestamp:   1690000000000000000,
+		Args:        []string{"key1", "value1", "key2", "value2"},
+	}
+}
@@ -87,6 +87,25 @@ func (j *JsonMarshalizer) IsInterfaceNil() bool {
 	return j == nil
 }
 
+// MarshalInto serializes obj and appends the result to dst
+func (j JsonMarshalizer) MarshalInto(obj interface{}, dst []byte) ([]byte, error) {
+	if obj == nil {
+		return nil, errors.New("NIL object to serilize from!")
+	}
+
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dst, encoded...), nil
+}
+
+// UnmarshalFrom deserializes src into obj
+func (j JsonMarshalizer) UnmarshalFrom(obj interface{}, src []byte) error {
+	return j.Unmarshal(obj, src)
+}
+
 //------- protobuf
 
 type ProtobufMarshalizer struct{}
@@ -113,6 +132,33 @@ func (x *ProtobufMarshalizer) IsInterfaceNil() bool {
 	return x == nil
 }
 
+// MarshalInto serializes obj and appends the result to dst. It uses the same
+// legacy proto.Message (Reset/String/ProtoMessage) as Marshal/Unmarshal above,
+// so it works with any generated type already handled by this marshalizer;
+// the legacy API has no append-in-place encoder, so this still allocates one
+// intermediate slice internally, it just avoids a second allocation for dst.
+func (x *ProtobufMarshalizer) MarshalInto(obj interface{}, dst []byte) ([]byte, error) {
+	msg, ok := obj.(proto.Message)
+	if !ok {
+		return nil, errors.New("can not serialize the object")
+	}
+
+	enc, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dst, enc...), nil
+}
+
+// UnmarshalFrom deserializes src into obj
+func (x *ProtobufMarshalizer) UnmarshalFrom(obj interface{}, src []byte) error {
+	if msg, ok := obj.(proto.Message); ok {
+		return proto.Unmarshal(src, msg)
+	}
+	return errors.New("obj does not implement proto.Message")
+}
+
 //------- stub
 
 type MarshalizerStub struct {
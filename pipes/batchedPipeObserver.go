@@ -0,0 +1,134 @@
+package pipes
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+var _ io.Writer = (*batchedPipeObserver)(nil)
+
+// batchedPipeObserver coalesces multiple marshalized log lines into a single
+// framed write, to avoid one pipe-write syscall per log line under high log rates.
+type batchedPipeObserver struct {
+	mutBuffer     sync.Mutex
+	writePipe     *os.File
+	buffer        []byte
+	count         uint32
+	maxBatchBytes int
+	flushInterval time.Duration
+	closeOnce     sync.Once
+	chanClose     chan struct{}
+	chanClosed    chan struct{}
+}
+
+// NewBatchedPipeObserver creates a new observer that buffers marshalized log lines
+// and periodically flushes them as a single batch frame through the pipe, instead
+// of writing each one individually. The buffer is also flushed as soon as it
+// reaches maxBatchBytes, and a background goroutine flushes it every flushInterval.
+func NewBatchedPipeObserver(writePipe *os.File, maxBatchBytes int, flushInterval time.Duration) *batchedPipeObserver {
+	observer := &batchedPipeObserver{
+		writePipe:     writePipe,
+		maxBatchBytes: maxBatchBytes,
+		flushInterval: flushInterval,
+		chanClose:     make(chan struct{}),
+		chanClosed:    make(chan struct{}),
+	}
+
+	go observer.continuouslyFlush()
+
+	return observer
+}
+
+// Write appends a marshalized log line to the pending batch, flushing it
+// immediately once the batch has reached maxBatchBytes.
+func (observer *batchedPipeObserver) Write(logLineMarshalized []byte) (int, error) {
+	observer.mutBuffer.Lock()
+
+	observer.appendToBuffer(logLineMarshalized)
+	shouldFlush := observer.maxBatchBytes > 0 && len(observer.buffer) >= observer.maxBatchBytes
+
+	var err error
+	if shouldFlush {
+		err = observer.flushUnderLock()
+	}
+
+	observer.mutBuffer.Unlock()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(logLineMarshalized), nil
+}
+
+func (observer *batchedPipeObserver) appendToBuffer(logLineMarshalized []byte) {
+	lengthPrefix := make([]byte, sizeOfUint32)
+	binary.LittleEndian.PutUint32(lengthPrefix, uint32(len(logLineMarshalized)))
+
+	observer.buffer = append(observer.buffer, lengthPrefix...)
+	observer.buffer = append(observer.buffer, logLineMarshalized...)
+	observer.count++
+}
+
+func (observer *batchedPipeObserver) continuouslyFlush() {
+	defer close(observer.chanClosed)
+
+	ticker := time.NewTicker(observer.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			observer.Flush()
+		case <-observer.chanClose:
+			observer.Flush()
+			return
+		}
+	}
+}
+
+// Flush writes out the pending batch, if any, as a single framed message.
+func (observer *batchedPipeObserver) Flush() error {
+	observer.mutBuffer.Lock()
+	defer observer.mutBuffer.Unlock()
+
+	return observer.flushUnderLock()
+}
+
+func (observer *batchedPipeObserver) flushUnderLock() error {
+	if observer.count == 0 {
+		return nil
+	}
+
+	countPrefix := make([]byte, sizeOfUint32)
+	binary.LittleEndian.PutUint32(countPrefix, observer.count)
+	payload := append(countPrefix, observer.buffer...)
+
+	frame := make([]byte, 0, sizeOfFrameType+sizeOfUint32+len(payload))
+	frame = append(frame, frameTypeBatch)
+
+	lengthPrefix := make([]byte, sizeOfUint32)
+	binary.LittleEndian.PutUint32(lengthPrefix, uint32(len(payload)))
+	frame = append(frame, lengthPrefix...)
+	frame = append(frame, payload...)
+
+	_, err := observer.writePipe.Write(frame)
+
+	observer.buffer = observer.buffer[:0]
+	observer.count = 0
+
+	return err
+}
+
+// Close stops the background flusher and drains any buffered log lines first
+func (observer *batchedPipeObserver) Close() error {
+	observer.closeOnce.Do(func() {
+		close(observer.chanClose)
+		<-observer.chanClosed
+	})
+
+	return nil
+}
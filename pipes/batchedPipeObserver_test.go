@@ -0,0 +1,124 @@
+package pipes
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/ElrondNetwork/elrond-go-logger/mock"
+)
+
+func readOneBatch(t *testing.T, readPipe *os.File, marshalizer logger.Marshalizer) []*logger.LogLine {
+	t.Helper()
+
+	frameType, err := readFrameTypeFrom(readPipe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frameType != frameTypeBatch {
+		t.Fatalf("expected frameTypeBatch, got %d", frameType)
+	}
+
+	logLines, err := readBatchFrameLogLines(readPipe, marshalizer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return logLines
+}
+
+// TestBatchedPipeObserver_FlushesOnMaxBatchBytes checks that the buffer is
+// flushed as soon as it grows past maxBatchBytes, without waiting for the
+// background ticker.
+func TestBatchedPipeObserver_FlushesOnMaxBatchBytes(t *testing.T) {
+	readPipe, writePipe, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readPipe.Close()
+	defer writePipe.Close()
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	line, err := marshalizer.Marshal(&logger.LogLineWrapper{Message: "a flush-triggering log line"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	observer := NewBatchedPipeObserver(writePipe, len(line), time.Hour)
+	defer observer.Close()
+
+	_, err = observer.Write(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logLines := readOneBatch(t, readPipe, marshalizer)
+	if len(logLines) != 1 || logLines[0].Message != "a flush-triggering log line" {
+		t.Fatalf("unexpected log lines: %+v", logLines)
+	}
+}
+
+// TestBatchedPipeObserver_FlushesOnTicker checks that a batch smaller than
+// maxBatchBytes still reaches the pipe once the background flusher ticks.
+func TestBatchedPipeObserver_FlushesOnTicker(t *testing.T) {
+	readPipe, writePipe, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readPipe.Close()
+	defer writePipe.Close()
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	line, err := marshalizer.Marshal(&logger.LogLineWrapper{Message: "a ticker-flushed log line"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	observer := NewBatchedPipeObserver(writePipe, len(line)*10, 10*time.Millisecond)
+	defer observer.Close()
+
+	_, err = observer.Write(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logLines := readOneBatch(t, readPipe, marshalizer)
+	if len(logLines) != 1 || logLines[0].Message != "a ticker-flushed log line" {
+		t.Fatalf("unexpected log lines: %+v", logLines)
+	}
+}
+
+// TestBatchedPipeObserver_CloseDrainsBuffer checks that any pending, not yet
+// flushed log line is written out as part of Close.
+func TestBatchedPipeObserver_CloseDrainsBuffer(t *testing.T) {
+	readPipe, writePipe, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readPipe.Close()
+	defer writePipe.Close()
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	line, err := marshalizer.Marshal(&logger.LogLineWrapper{Message: "a close-drained log line"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	observer := NewBatchedPipeObserver(writePipe, len(line)*10, time.Hour)
+
+	_, err = observer.Write(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = observer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logLines := readOneBatch(t, readPipe, marshalizer)
+	if len(logLines) != 1 || logLines[0].Message != "a close-drained log line" {
+		t.Fatalf("unexpected log lines: %+v", logLines)
+	}
+}
@@ -0,0 +1,80 @@
+package pipes
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-logger/mock"
+)
+
+// BenchmarkPipeObserver_Write measures allocs/op for writing a ~512-byte log
+// line frame. The framePool in Write should keep this at (or near) zero
+// allocations per op once the pool has warmed up.
+func BenchmarkPipeObserver_Write(b *testing.B) {
+	readPipe, writePipe, err := os.Pipe()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer readPipe.Close()
+	defer writePipe.Close()
+
+	go func() {
+		_, _ = io.Copy(io.Discard, readPipe)
+	}()
+
+	observer := NewPipeObserver(writePipe)
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	logLineMarshalized, err := marshalizer.Marshal(mock.NewBenchmarkLogLineWrapper())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := observer.Write(logLineMarshalized)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkReadLogLinePayload measures allocs/op for decoding a ~512-byte log
+// line payload. The payloadPool in readLogLinePayload removes the per-op
+// allocation of the read buffer once the pool has warmed up; the allocations
+// left per op are the returned LogLineWrapper and its decoded fields.
+func BenchmarkReadLogLinePayload(b *testing.B) {
+	readPipe, writePipe, err := os.Pipe()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer readPipe.Close()
+	defer writePipe.Close()
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	payload, err := marshalizer.Marshal(mock.NewBenchmarkLogLineWrapper())
+	if err != nil {
+		b.Fatal(err)
+	}
+	length := uint32(len(payload))
+
+	go func() {
+		for {
+			_, err := writePipe.Write(payload)
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := readLogLinePayload(readPipe, marshalizer, length)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
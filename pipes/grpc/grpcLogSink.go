@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+var _ LogStreamServer = (*grpcLogSink)(nil)
+
+type grpcLogSink struct {
+	loggerSink logger.Logger
+	server     *grpc.Server
+}
+
+// NewGrpcLogSink creates a gRPC server that accepts "Stream" connections from
+// remote "grpcObserver"s, forwards every received log line to sink, and pushes
+// the current logging profile back to every connected observer whenever it
+// changes, same as "SubscribeToProfileChange" does for in-process listeners.
+func NewGrpcLogSink(listener net.Listener, sink logger.Logger) (*grpcLogSink, error) {
+	logSink := &grpcLogSink{
+		loggerSink: sink,
+		server:     grpc.NewServer(),
+	}
+
+	RegisterLogStreamServer(logSink.server, logSink)
+
+	go func() {
+		err := logSink.server.Serve(listener)
+		if err != nil {
+			log.Debug("grpcLogSink.Serve stopped", "err", err)
+		}
+	}()
+
+	return logSink, nil
+}
+
+// Stream implements LogStreamServer. It reads marshalized log lines sent by a
+// remote observer and forwards them to the local logger sink, while pushing
+// profile updates back for as long as the stream stays open.
+func (logSink *grpcLogSink) Stream(stream LogStream_StreamServer) error {
+	profileForwarder := &grpcProfileForwarder{stream: stream}
+	logger.SubscribeToProfileChange(profileForwarder)
+	defer logger.UnsubscribeFromProfileChange(profileForwarder)
+
+	err := profileForwarder.send(&ProfileUpdateMessage{Profile: logger.GetCurrentProfile()})
+	if err != nil {
+		return err
+	}
+
+	for {
+		message, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		logLineWrapper := &logger.LogLineWrapper{}
+		err = proto.Unmarshal(message.GetPayload(), logLineWrapper)
+		if err != nil {
+			logSink.loggerSink.Warn("grpcLogSink.Stream: cannot unmarshal log line", "err", err)
+			continue
+		}
+
+		logSink.loggerSink.Log(recoverLogLine(logLineWrapper))
+	}
+}
+
+// Close stops the gRPC server, closing every open observer connection
+func (logSink *grpcLogSink) Close() {
+	logSink.server.GracefulStop()
+}
+
+type grpcProfileForwarder struct {
+	stream  LogStream_StreamServer
+	mutSend sync.Mutex
+}
+
+// send serializes writes to the stream: grpc-go streams are not safe for
+// concurrent SendMsg calls, and the initial profile push in "Stream" races
+// with "OnProfileChanged" notifications coming from other goroutines.
+func (forwarder *grpcProfileForwarder) send(update *ProfileUpdateMessage) error {
+	forwarder.mutSend.Lock()
+	defer forwarder.mutSend.Unlock()
+
+	return forwarder.stream.Send(update)
+}
+
+// OnProfileChanged pushes the new logging profile to the connected observer.
+// Errors are ignored here: a broken stream is already being torn down by
+// "Stream", which will unsubscribe this forwarder once Recv fails.
+func (forwarder *grpcProfileForwarder) OnProfileChanged() {
+	_ = forwarder.send(&ProfileUpdateMessage{Profile: logger.GetCurrentProfile()})
+}
+
+func recoverLogLine(wrapper *logger.LogLineWrapper) *logger.LogLine {
+	logLine := &logger.LogLine{
+		LoggerName:  wrapper.LoggerName,
+		Correlation: wrapper.Correlation,
+		Message:     wrapper.Message,
+		LogLevel:    logger.LogLevel(wrapper.LogLevel),
+		Args:        make([]interface{}, len(wrapper.Args)),
+		Timestamp:   time.Unix(0, wrapper.Timestamp),
+	}
+
+	for i, str := range wrapper.Args {
+		logLine.Args[i] = str
+	}
+
+	return logLine
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"google.golang.org/grpc"
+)
+
+var log = logger.GetOrCreate("pipes/grpc")
+
+var _ io.Writer = (*grpcObserver)(nil)
+
+type grpcObserver struct {
+	client  LogStreamClient
+	stream  LogStream_StreamClient
+	cancel  context.CancelFunc
+	mutSend sync.Mutex
+}
+
+// NewGrpcObserver creates a new observer that can be attached to any logger,
+// and which ships the log data to a remote "grpcLogSink" over a bidirectional
+// gRPC stream opened on the provided connection.
+func NewGrpcObserver(conn *grpc.ClientConn) (*grpcObserver, error) {
+	client := NewLogStreamClient(conn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Stream(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	observer := &grpcObserver{
+		client: client,
+		stream: stream,
+		cancel: cancel,
+	}
+
+	go observer.continuouslyReadProfileUpdates()
+
+	return observer, nil
+}
+
+// Write sends a marshalized log line to the remote sink through the gRPC stream.
+// grpc-go streams are not safe for concurrent SendMsg calls, and the logger this
+// observer is attached to may call Write from multiple goroutines at once, so
+// sends are serialized under mutSend.
+func (observer *grpcObserver) Write(logLineMarshalized []byte) (int, error) {
+	observer.mutSend.Lock()
+	err := observer.stream.Send(&LogLineMessage{Payload: logLineMarshalized})
+	observer.mutSend.Unlock()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(logLineMarshalized), nil
+}
+
+func (observer *grpcObserver) continuouslyReadProfileUpdates() {
+	for {
+		update, err := observer.stream.Recv()
+		if err != nil {
+			log.Debug("grpcObserver.continuouslyReadProfileUpdates: stream closed", "err", err)
+			return
+		}
+
+		err = logger.SetLogLevelPattern(update.GetProfile())
+		if err != nil {
+			log.Warn("grpcObserver.continuouslyReadProfileUpdates: cannot apply profile", "err", err)
+		}
+	}
+}
+
+// Close ends the gRPC stream opened towards the remote sink
+func (observer *grpcObserver) Close() error {
+	observer.cancel()
+	return observer.stream.CloseSend()
+}
@@ -0,0 +1,144 @@
+package pipes
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+// pipeMessenger bundles a log-line observer and forwarder together with a
+// logging-profile forwarder and receiver, over a single pair of pipes, so a
+// parent and a child process can exchange both log lines and profile changes
+// without each one composing the four pieces by hand. Log and profile frames
+// share the same pipe and are told apart by their frame type tag, so a single
+// goroutine reads and dispatches every incoming frame.
+type pipeMessenger struct {
+	observer         *pipeObserver
+	profileForwarder *pipeProfileForwarder
+
+	readPipe    *os.File
+	marshalizer logger.Marshalizer
+	loggerSink  logger.Logger
+
+	chanClose  chan struct{}
+	chanClosed chan struct{}
+}
+
+// NewPipeMessenger creates a messenger that writes log lines and logging profile
+// changes through writePipe, and reads the same kind of frames coming from the
+// other end through readPipe, forwarding log lines to sink and applying profile
+// changes to the local logger configuration.
+func NewPipeMessenger(writePipe *os.File, readPipe *os.File, marshalizer logger.Marshalizer, sink logger.Logger) *pipeMessenger {
+	mutWrite := new(sync.Mutex)
+
+	return &pipeMessenger{
+		observer:         newPipeObserver(writePipe, mutWrite),
+		profileForwarder: newPipeProfileForwarder(writePipe, mutWrite),
+		readPipe:         readPipe,
+		marshalizer:      marshalizer,
+		loggerSink:       sink,
+		chanClose:        make(chan struct{}),
+		chanClosed:       make(chan struct{}),
+	}
+}
+
+// Observer returns the io.Writer to attach to a logger so its lines get shipped
+// to the other end of the messenger
+func (messenger *pipeMessenger) Observer() io.Writer {
+	return messenger.observer
+}
+
+// Start begins forwarding local logging profile changes and reading incoming frames
+func (messenger *pipeMessenger) Start() {
+	messenger.profileForwarder.StartFowarding()
+	go messenger.continuouslyReadFrames()
+}
+
+func (messenger *pipeMessenger) continuouslyReadFrames() {
+	defer close(messenger.chanClosed)
+
+	for {
+		err := messenger.readAndHandleFrame()
+		if err != nil {
+			messenger.reportReadError(err)
+			return
+		}
+	}
+}
+
+func (messenger *pipeMessenger) readAndHandleFrame() error {
+	frameType, err := readFrameTypeFrom(messenger.readPipe)
+	if err != nil {
+		return err
+	}
+
+	var logLines []*logger.LogLine
+	switch frameType {
+	case frameTypeProfile:
+		return messenger.handleProfileFrame()
+	case frameTypeSingle:
+		var logLine *logger.LogLine
+		logLine, err = readSingleFrameLogLine(messenger.readPipe, messenger.marshalizer)
+		logLines = []*logger.LogLine{logLine}
+	case frameTypeBatch:
+		logLines, err = readBatchFrameLogLines(messenger.readPipe, messenger.marshalizer)
+	default:
+		err = skipFrame(messenger.readPipe)
+		if err != nil {
+			return err
+		}
+		messenger.loggerSink.Warn("pipeMessenger.readAndHandleFrame: ignoring unrecognized frame type", "frameType", frameType)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, logLine := range logLines {
+		messenger.loggerSink.Log(logLine)
+	}
+
+	return nil
+}
+
+// handleProfileFrame reads a profile frame and applies it. Only I/O errors on
+// the pipe are returned (and end the read loop, same as a log frame would); an
+// invalid profile coming from the peer is logged and otherwise ignored, so one
+// bad profile string can't permanently kill log forwarding.
+func (messenger *pipeMessenger) handleProfileFrame() error {
+	length, err := readLengthFrom(messenger.readPipe)
+	if err != nil {
+		return err
+	}
+
+	buffer := make([]byte, length)
+	_, err = io.ReadFull(messenger.readPipe, buffer)
+	if err != nil {
+		return err
+	}
+
+	err = logger.SetLogLevelPattern(string(buffer))
+	if err != nil {
+		messenger.loggerSink.Error("pipeMessenger.handleProfileFrame: cannot apply profile", "err", err)
+	}
+
+	return nil
+}
+
+func (messenger *pipeMessenger) reportReadError(err error) {
+	select {
+	case <-messenger.chanClose:
+	default:
+		messenger.loggerSink.Error("pipeMessenger read error", "err", err)
+	}
+}
+
+// Close stops forwarding local profile changes and stops the background reader
+func (messenger *pipeMessenger) Close() {
+	messenger.profileForwarder.Close()
+	close(messenger.chanClose)
+	_ = messenger.readPipe.Close()
+	<-messenger.chanClosed
+}
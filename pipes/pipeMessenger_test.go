@@ -0,0 +1,75 @@
+package pipes
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/ElrondNetwork/elrond-go-logger/mock"
+)
+
+// testLoggerSink is a minimal logger.Logger double: it embeds the (nil) interface
+// so it satisfies logger.Logger, and overrides only the methods this test exercises.
+type testLoggerSink struct {
+	logger.Logger
+}
+
+func (s *testLoggerSink) Log(_ *logger.LogLine)            {}
+func (s *testLoggerSink) Error(_ string, _ ...interface{}) {}
+func (s *testLoggerSink) Warn(_ string, _ ...interface{})  {}
+
+func TestPipeMessenger_ProfileChangeIsAppliedOnBothEnds(t *testing.T) {
+	abRead, abWrite, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer abRead.Close()
+	defer abWrite.Close()
+
+	baRead, baWrite, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer baRead.Close()
+	defer baWrite.Close()
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	messengerA := NewPipeMessenger(abWrite, baRead, marshalizer, &testLoggerSink{})
+	messengerB := NewPipeMessenger(baWrite, abRead, marshalizer, &testLoggerSink{})
+
+	messengerA.Start()
+	messengerB.Start()
+
+	// Parent (A) changes its profile: it should be shipped to, and applied by, the child (B).
+	err = logger.SetLogLevelPattern("*:DEBUG")
+	if err != nil {
+		t.Fatal(err)
+	}
+	messengerA.profileForwarder.forwardProfile()
+	waitForProfile(t, "*:DEBUG")
+
+	// Child (B) changes its profile: it should be shipped back to, and applied by, the parent (A).
+	err = logger.SetLogLevelPattern("*:INFO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	messengerB.profileForwarder.forwardProfile()
+	waitForProfile(t, "*:INFO")
+}
+
+// waitForProfile polls logger.GetCurrentProfile, since frame delivery happens on a
+// background goroutine.
+func waitForProfile(t *testing.T, expected string) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if logger.GetCurrentProfile() == expected {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("profile %q was not applied in time", expected)
+}
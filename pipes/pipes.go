@@ -2,9 +2,9 @@ package pipes
 
 import (
 	"encoding/binary"
-	"fmt"
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	logger "github.com/ElrondNetwork/elrond-go-logger"
@@ -12,9 +12,20 @@ import (
 
 var _ io.Writer = (*pipeObserver)(nil)
 
+var log = logger.GetOrCreate("pipes")
+
 const sizeOfUint32 = 4
+const sizeOfFrameType = 1
+
+// frame type tags, written as the first byte of every message sent through the pipe
+const (
+	frameTypeSingle  byte = 0
+	frameTypeBatch   byte = 1
+	frameTypeProfile byte = 2
+)
 
 type pipeObserver struct {
+	mutWrite  *sync.Mutex
 	writePipe *os.File
 }
 
@@ -22,28 +33,50 @@ type pipeObserver struct {
 // and which writes the log data through a pipe.
 // Ultimately, the data will be read by a "pipeObserverForwarder"
 func NewPipeObserver(writePipe *os.File) *pipeObserver {
+	return newPipeObserver(writePipe, new(sync.Mutex))
+}
+
+// newPipeObserver creates a pipeObserver that serializes its writes through
+// mutWrite, so that a caller sharing writePipe with another writer (such as a
+// "pipeProfileForwarder" inside a "pipeMessenger") can pass in the same mutex
+// and keep frames from interleaving on the wire.
+func newPipeObserver(writePipe *os.File, mutWrite *sync.Mutex) *pipeObserver {
 	return &pipeObserver{
 		writePipe: writePipe,
+		mutWrite:  mutWrite,
 	}
 }
 
-// Write sends a marshalized log line through the pipe, to be captured by the forwarder
-// TODO: We have to ensure this is thread-safe
+// framePool holds scratch buffers for assembling outgoing frames. Buffers grow
+// to the largest frame written so far and are reused across calls, instead of
+// allocating a fresh []byte for every log line.
+var framePool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 512)
+		return &buf
+	},
+}
+
+// Write sends a marshalized log line through the pipe, to be captured by the forwarder.
+// The frame type, length and payload are assembled into a single, pooled buffer and
+// written with one syscall, under a mutex, so that concurrent writers cannot interleave
+// their frames.
 func (observer *pipeObserver) Write(logLineMarshalized []byte) (int, error) {
-	length := len(logLineMarshalized)
-	err := observer.writeLogLineLength(length)
+	bufPtr := framePool.Get().(*[]byte)
+	frame := appendFrameWithType((*bufPtr)[:0], frameTypeSingle, logLineMarshalized)
+	*bufPtr = frame
+
+	observer.mutWrite.Lock()
+	_, err := observer.writePipe.Write(frame)
+	observer.mutWrite.Unlock()
+
+	framePool.Put(bufPtr)
+
 	if err != nil {
 		return 0, err
 	}
 
-	return observer.writePipe.Write(logLineMarshalized)
-}
-
-func (observer *pipeObserver) writeLogLineLength(length int) error {
-	buffer := make([]byte, sizeOfUint32)
-	binary.LittleEndian.PutUint32(buffer, uint32(length))
-	_, err := observer.writePipe.Write(buffer)
-	return err
+	return len(logLineMarshalized), nil
 }
 
 type pipeObserverForwarder struct {
@@ -68,34 +101,121 @@ func (forwarder *pipeObserverForwarder) StartFowarding() {
 
 func (forwarder *pipeObserverForwarder) continuouslyReadLogLines() {
 	for {
-		logLine, err := forwarder.readLogLine()
+		logLines, err := forwarder.readFrame()
 		if err != nil {
 			forwarder.loggerSink.Error("continuouslyReadLogLines error", "err", err)
 			break
 		}
 
-		forwarder.loggerSink.Log(logLine)
+		for _, logLine := range logLines {
+			forwarder.loggerSink.Log(logLine)
+		}
 	}
 }
 
-func (forwarder *pipeObserverForwarder) readLogLine() (*logger.LogLine, error) {
-	length, err := forwarder.readLogLineLength()
+// readFrame reads one frame from the pipe and returns the log line(s) it carries.
+// A "single" frame carries exactly one log line, a "batch" frame carries however
+// many were coalesced together by a "batchedPipeObserver". Any other frame type
+// (e.g. "frameTypeProfile", meant for a "pipeMessenger"/"pipeProfileReceiver") is
+// drained and discarded instead of being mis-decoded as a log line: a standalone
+// forwarder has nowhere to route a profile update, but a stray one shouldn't kill
+// its read loop either.
+func (forwarder *pipeObserverForwarder) readFrame() ([]*logger.LogLine, error) {
+	frameType, err := readFrameTypeFrom(forwarder.readPipe)
 	if err != nil {
 		return nil, err
 	}
 
-	logLineWrapper, err := forwarder.readLogLinePayload(length)
+	switch frameType {
+	case frameTypeSingle:
+		logLine, err := readSingleFrameLogLine(forwarder.readPipe, forwarder.marshalizer)
+		if err != nil {
+			return nil, err
+		}
+		return []*logger.LogLine{logLine}, nil
+	case frameTypeBatch:
+		return readBatchFrameLogLines(forwarder.readPipe, forwarder.marshalizer)
+	default:
+		err := skipFrame(forwarder.readPipe)
+		if err != nil {
+			return nil, err
+		}
+		forwarder.loggerSink.Warn("pipeObserverForwarder.readFrame: ignoring unrecognized frame type", "frameType", frameType)
+		return nil, nil
+	}
+}
+
+// skipFrame reads and discards the length-prefixed payload of a frame whose
+// type this reader doesn't know how to handle, keeping the pipe in sync for
+// the next frame.
+func skipFrame(readPipe *os.File) error {
+	length, err := readLengthFrom(readPipe)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.CopyN(io.Discard, readPipe, int64(length))
+	return err
+}
+
+func readFrameTypeFrom(readPipe *os.File) (byte, error) {
+	buffer := make([]byte, sizeOfFrameType)
+	_, err := io.ReadFull(readPipe, buffer)
+	if err != nil {
+		return 0, err
+	}
+
+	return buffer[0], nil
+}
+
+func readSingleFrameLogLine(readPipe *os.File, marshalizer logger.Marshalizer) (*logger.LogLine, error) {
+	length, err := readLengthFrom(readPipe)
+	if err != nil {
+		return nil, err
+	}
+
+	logLineWrapper, err := readLogLinePayload(readPipe, marshalizer, length)
+	if err != nil {
+		return nil, err
+	}
+
+	return recoverLogLine(logLineWrapper), nil
+}
+
+// readBatchFrameLogLines reads a frame produced by "batchedPipeObserver": a total
+// length, followed by a count of log lines, followed by that many length-prefixed payloads.
+func readBatchFrameLogLines(readPipe *os.File, marshalizer logger.Marshalizer) ([]*logger.LogLine, error) {
+	_, err := readLengthFrom(readPipe)
 	if err != nil {
 		return nil, err
 	}
 
-	logLine := forwarder.recoverLogLine(logLineWrapper)
-	return logLine, nil
+	count, err := readLengthFrom(readPipe)
+	if err != nil {
+		return nil, err
+	}
+
+	logLines := make([]*logger.LogLine, 0, count)
+	for i := uint32(0); i < count; i++ {
+		length, err := readLengthFrom(readPipe)
+		if err != nil {
+			return nil, err
+		}
+
+		logLineWrapper, err := readLogLinePayload(readPipe, marshalizer, length)
+		if err != nil {
+			return nil, err
+		}
+
+		logLines = append(logLines, recoverLogLine(logLineWrapper))
+	}
+
+	return logLines, nil
 }
 
-func (forwarder *pipeObserverForwarder) readLogLineLength() (uint32, error) {
+func readLengthFrom(readPipe *os.File) (uint32, error) {
 	buffer := make([]byte, sizeOfUint32)
-	_, err := io.ReadFull(forwarder.readPipe, buffer)
+	_, err := io.ReadFull(readPipe, buffer)
 	if err != nil {
 		return 0, err
 	}
@@ -104,23 +224,52 @@ func (forwarder *pipeObserverForwarder) readLogLineLength() (uint32, error) {
 	return length, nil
 }
 
-func (forwarder *pipeObserverForwarder) readLogLinePayload(length uint32) (*logger.LogLineWrapper, error) {
-	buffer := make([]byte, length)
-	_, err := io.ReadFull(forwarder.readPipe, buffer)
+// payloadPool holds scratch buffers for reading incoming frame payloads. Buffers
+// grow to the largest frame read so far and are reused across calls, instead of
+// allocating a fresh []byte for every log line.
+var payloadPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 512)
+		return &buf
+	},
+}
+
+func readLogLinePayload(readPipe *os.File, marshalizer logger.Marshalizer, length uint32) (*logger.LogLineWrapper, error) {
+	bufPtr := payloadPool.Get().(*[]byte)
+	buffer := growToSize(*bufPtr, int(length))
+
+	_, err := io.ReadFull(readPipe, buffer)
 	if err != nil {
+		payloadPool.Put(bufPtr)
 		return nil, err
 	}
 
-	logLine := &logger.LogLineWrapper{}
-	err = forwarder.marshalizer.Unmarshal(logLine, buffer)
+	logLineWrapper := &logger.LogLineWrapper{}
+	if streamMarshalizer, ok := marshalizer.(logger.StreamMarshalizer); ok {
+		err = streamMarshalizer.UnmarshalFrom(logLineWrapper, buffer)
+	} else {
+		err = marshalizer.Unmarshal(logLineWrapper, buffer)
+	}
+
+	*bufPtr = buffer
+	payloadPool.Put(bufPtr)
+
 	if err != nil {
 		return nil, err
 	}
 
-	return logLine, nil
+	return logLineWrapper, nil
+}
+
+func growToSize(buffer []byte, size int) []byte {
+	if cap(buffer) < size {
+		return make([]byte, size)
+	}
+
+	return buffer[:size]
 }
 
-func (forwarder *pipeObserverForwarder) recoverLogLine(wrapper *logger.LogLineWrapper) *logger.LogLine {
+func recoverLogLine(wrapper *logger.LogLineWrapper) *logger.LogLine {
 	logLine := &logger.LogLine{
 		LoggerName:  wrapper.LoggerName,
 		Correlation: wrapper.Correlation,
@@ -138,14 +287,24 @@ func (forwarder *pipeObserverForwarder) recoverLogLine(wrapper *logger.LogLineWr
 }
 
 type pipeProfileForwarder struct {
+	mutWrite  *sync.Mutex
 	writePipe *os.File
 }
 
 // NewPipeProfileForwarder creates a new profile forwarder,
 // which forwards logging profiles through pipe
 func NewPipeProfileForwarder(writePipe *os.File) *pipeProfileForwarder {
+	return newPipeProfileForwarder(writePipe, new(sync.Mutex))
+}
+
+// newPipeProfileForwarder creates a pipeProfileForwarder that serializes its
+// writes through mutWrite, so that a caller sharing writePipe with another
+// writer (such as a "pipeObserver" inside a "pipeMessenger") can pass in the
+// same mutex and keep frames from interleaving on the wire.
+func newPipeProfileForwarder(writePipe *os.File, mutWrite *sync.Mutex) *pipeProfileForwarder {
 	return &pipeProfileForwarder{
 		writePipe: writePipe,
+		mutWrite:  mutWrite,
 	}
 }
 
@@ -158,16 +317,101 @@ func (forwarder *pipeProfileForwarder) OnProfileChanged() {
 	forwarder.forwardProfile()
 }
 
+// forwardProfile writes the current logging profile through the pipe, using the
+// same length-prefixed framing as log lines but tagged with "frameTypeProfile"
+// so the reading side can tell the two kinds of frames apart.
 func (forwarder *pipeProfileForwarder) forwardProfile() {
 	profile := logger.GetCurrentProfile()
-	fmt.Println(profile)
+	frame := buildFrameWithType(frameTypeProfile, []byte(profile))
+
+	forwarder.mutWrite.Lock()
+	_, err := forwarder.writePipe.Write(frame)
+	forwarder.mutWrite.Unlock()
+
+	if err != nil {
+		log.Error("pipeProfileForwarder.forwardProfile error", "err", err)
+	}
 }
 
 func (forwarder *pipeProfileForwarder) Close() {
 	logger.UnsubscribeFromProfileChange(forwarder)
 }
 
+// pipeProfileReceiver reads logging profiles forwarded by a "pipeProfileForwarder"
+// through a pipe, and applies them to the local logger configuration.
 type pipeProfileReceiver struct {
+	readPipe   *os.File
+	chanClose  chan struct{}
+	chanClosed chan struct{}
 }
 
-// TODO Messenger = sender + receiver.
+// NewPipeProfileReceiver creates a new profile receiver, which reads logging
+// profiles sent through a pipe and applies them locally
+func NewPipeProfileReceiver(readPipe *os.File) *pipeProfileReceiver {
+	return &pipeProfileReceiver{
+		readPipe:   readPipe,
+		chanClose:  make(chan struct{}),
+		chanClosed: make(chan struct{}),
+	}
+}
+
+func (receiver *pipeProfileReceiver) StartReceiving() {
+	go receiver.continuouslyReadProfiles()
+}
+
+func (receiver *pipeProfileReceiver) continuouslyReadProfiles() {
+	defer close(receiver.chanClosed)
+
+	for {
+		profile, err := receiver.readProfileFrame()
+		if err != nil {
+			receiver.reportReadError(err)
+			return
+		}
+
+		receiver.applyProfile(profile)
+	}
+}
+
+func (receiver *pipeProfileReceiver) readProfileFrame() (string, error) {
+	_, err := readFrameTypeFrom(receiver.readPipe)
+	if err != nil {
+		return "", err
+	}
+
+	length, err := readLengthFrom(receiver.readPipe)
+	if err != nil {
+		return "", err
+	}
+
+	buffer := make([]byte, length)
+	_, err = io.ReadFull(receiver.readPipe, buffer)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buffer), nil
+}
+
+func (receiver *pipeProfileReceiver) applyProfile(profile string) {
+	err := logger.SetLogLevelPattern(profile)
+	if err != nil {
+		log.Error("pipeProfileReceiver.applyProfile error", "err", err)
+	}
+}
+
+func (receiver *pipeProfileReceiver) reportReadError(err error) {
+	select {
+	case <-receiver.chanClose:
+	default:
+		log.Error("pipeProfileReceiver.continuouslyReadProfiles error", "err", err)
+	}
+}
+
+// Close stops the background reader, by closing the read pipe so any blocked
+// read unblocks with an error, and waits for the reader goroutine to exit
+func (receiver *pipeProfileReceiver) Close() {
+	close(receiver.chanClose)
+	_ = receiver.readPipe.Close()
+	<-receiver.chanClosed
+}
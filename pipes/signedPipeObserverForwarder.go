@@ -0,0 +1,105 @@
+package pipes
+
+import (
+	"crypto/hmac"
+	"io"
+	"os"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+// signedPipeObserverForwarder reads frames produced by a "signedPipeObserver",
+// verifies their HMAC-SHA256 signature in constant time and forwards the
+// contained log line to a generic logger sink. Frames that fail verification
+// are dropped (and reported to the sink) instead of being unmarshalized.
+type signedPipeObserverForwarder struct {
+	readPipe     *os.File
+	marshalizer  logger.Marshalizer
+	loggerSink   logger.Logger
+	sharedSecret []byte
+}
+
+// NewSignedPipeObserverForwarder creates a new forwarder for a signed pipe
+// transport. Pass an empty sharedSecret to accept only unsigned frames.
+func NewSignedPipeObserverForwarder(readPipe *os.File, marshalizer logger.Marshalizer, loggerSink logger.Logger, sharedSecret string) *signedPipeObserverForwarder {
+	return &signedPipeObserverForwarder{
+		readPipe:     readPipe,
+		marshalizer:  marshalizer,
+		loggerSink:   loggerSink,
+		sharedSecret: []byte(sharedSecret),
+	}
+}
+
+func (forwarder *signedPipeObserverForwarder) StartFowarding() {
+	go forwarder.continuouslyReadLogLines()
+}
+
+func (forwarder *signedPipeObserverForwarder) continuouslyReadLogLines() {
+	for {
+		logLine, err := forwarder.readLogLine()
+		if err != nil {
+			forwarder.loggerSink.Error("continuouslyReadLogLines error", "err", err)
+			break
+		}
+
+		if logLine == nil {
+			continue
+		}
+
+		forwarder.loggerSink.Log(logLine)
+	}
+}
+
+func (forwarder *signedPipeObserverForwarder) readLogLine() (*logger.LogLine, error) {
+	frameType, err := readFrameTypeFrom(forwarder.readPipe)
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := readLengthFrom(forwarder.readPipe)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(forwarder.readPipe, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	logLineMarshalized, ok := forwarder.verifyAndUnwrap(frameType, payload)
+	if !ok {
+		forwarder.loggerSink.Error("signedPipeObserverForwarder: dropping frame that failed signature verification")
+		return nil, nil
+	}
+
+	logLineWrapper := &logger.LogLineWrapper{}
+	err = forwarder.marshalizer.Unmarshal(logLineWrapper, logLineMarshalized)
+	if err != nil {
+		return nil, err
+	}
+
+	return recoverLogLine(logLineWrapper), nil
+}
+
+// verifyAndUnwrap checks the frame's signature, when one is expected, and
+// returns the marshalized log line carried inside it.
+func (forwarder *signedPipeObserverForwarder) verifyAndUnwrap(frameType byte, payload []byte) ([]byte, bool) {
+	if frameType != frameTypeSigned {
+		return payload, len(forwarder.sharedSecret) == 0
+	}
+
+	if len(forwarder.sharedSecret) == 0 || len(payload) < sizeOfHmacSha256 {
+		return nil, false
+	}
+
+	signature := payload[:sizeOfHmacSha256]
+	logLineMarshalized := payload[sizeOfHmacSha256:]
+	expectedSignature := computeSignature(forwarder.sharedSecret, logLineMarshalized)
+
+	if !hmac.Equal(signature, expectedSignature) {
+		return nil, false
+	}
+
+	return logLineMarshalized, true
+}
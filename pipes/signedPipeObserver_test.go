@@ -0,0 +1,177 @@
+package pipes
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/ElrondNetwork/elrond-go-logger/mock"
+)
+
+// countingErrorSink is a logger.Logger double that counts Error calls, so
+// tests can assert a rejected frame was reported without inspecting logs.
+type countingErrorSink struct {
+	logger.Logger
+	mut       sync.Mutex
+	errCalled int
+}
+
+func (s *countingErrorSink) Log(_ *logger.LogLine)           {}
+func (s *countingErrorSink) Warn(_ string, _ ...interface{}) {}
+func (s *countingErrorSink) Error(_ string, _ ...interface{}) {
+	s.mut.Lock()
+	s.errCalled++
+	s.mut.Unlock()
+}
+
+func (s *countingErrorSink) errorCount() int {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return s.errCalled
+}
+
+func TestSignedPipeObserver_RoundTrip(t *testing.T) {
+	readPipe, writePipe, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readPipe.Close()
+	defer writePipe.Close()
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	line, err := marshalizer.Marshal(&logger.LogLineWrapper{Message: "a signed log line"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &countingErrorSink{}
+	observer := NewSignedPipeObserver(writePipe, "s3cr3t")
+	forwarder := NewSignedPipeObserverForwarder(readPipe, marshalizer, sink, "s3cr3t")
+
+	_, err = observer.Write(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logLine, err := forwarder.readLogLine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logLine == nil || logLine.Message != "a signed log line" {
+		t.Fatalf("unexpected log line: %+v", logLine)
+	}
+	if sink.errorCount() != 0 {
+		t.Fatalf("expected no errors, got %d", sink.errorCount())
+	}
+}
+
+func TestSignedPipeObserver_TamperedSignatureIsRejected(t *testing.T) {
+	readPipe, writePipe, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readPipe.Close()
+	defer writePipe.Close()
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	line, err := marshalizer.Marshal(&logger.LogLineWrapper{Message: "forged"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &countingErrorSink{}
+	// Observer and forwarder disagree on the shared secret, simulating a
+	// frame signed (or forged) with the wrong key.
+	observer := NewSignedPipeObserver(writePipe, "s3cr3t")
+	forwarder := NewSignedPipeObserverForwarder(readPipe, marshalizer, sink, "a-different-secret")
+
+	_, err = observer.Write(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logLine, err := forwarder.readLogLine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logLine != nil {
+		t.Fatalf("expected the frame to be dropped, got %+v", logLine)
+	}
+	if sink.errorCount() != 1 {
+		t.Fatalf("expected the rejection to be reported once, got %d", sink.errorCount())
+	}
+}
+
+func TestSignedPipeObserver_EmptySecretFallback(t *testing.T) {
+	readPipe, writePipe, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readPipe.Close()
+	defer writePipe.Close()
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	line, err := marshalizer.Marshal(&logger.LogLineWrapper{Message: "unsigned"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &countingErrorSink{}
+	observer := NewSignedPipeObserver(writePipe, "")
+	forwarder := NewSignedPipeObserverForwarder(readPipe, marshalizer, sink, "")
+
+	_, err = observer.Write(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logLine, err := forwarder.readLogLine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logLine == nil || logLine.Message != "unsigned" {
+		t.Fatalf("unexpected log line: %+v", logLine)
+	}
+	if sink.errorCount() != 0 {
+		t.Fatalf("expected no errors, got %d", sink.errorCount())
+	}
+}
+
+func TestSignedPipeObserver_UnsignedFrameRejectedWhenForwarderExpectsSigning(t *testing.T) {
+	readPipe, writePipe, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readPipe.Close()
+	defer writePipe.Close()
+
+	marshalizer := &mock.ProtobufMarshalizer{}
+	line, err := marshalizer.Marshal(&logger.LogLineWrapper{Message: "unsigned"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &countingErrorSink{}
+	// Observer has no local secret (sends unsigned frames), but the forwarder
+	// requires signing: the frame must be rejected, not silently accepted.
+	observer := NewSignedPipeObserver(writePipe, "")
+	forwarder := NewSignedPipeObserverForwarder(readPipe, marshalizer, sink, "s3cr3t")
+
+	_, err = observer.Write(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logLine, err := forwarder.readLogLine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logLine != nil {
+		t.Fatalf("expected the unsigned frame to be dropped, got %+v", logLine)
+	}
+	if sink.errorCount() != 1 {
+		t.Fatalf("expected the rejection to be reported once, got %d", sink.errorCount())
+	}
+}
@@ -0,0 +1,14 @@
+package logger
+
+// StreamMarshalizer is an optional extension of Marshalizer for transports that
+// see a very high message rate (such as the pipe observers) and want to reuse a
+// caller-provided buffer instead of allocating a fresh one for every call.
+// Implementors that can't avoid the allocation are free to ignore dst/return a
+// newly allocated slice; callers should always use the returned slice, not dst.
+type StreamMarshalizer interface {
+	// MarshalInto serializes obj, appending the result to dst, and returns the
+	// (possibly reallocated) slice holding the serialized data
+	MarshalInto(obj interface{}, dst []byte) ([]byte, error)
+	// UnmarshalFrom deserializes src into obj
+	UnmarshalFrom(obj interface{}, src []byte) error
+}